@@ -0,0 +1,70 @@
+// Command route dumps the kernel's IPv4 and IPv6 routing tables, similar to
+// `netstat -nr` on macOS/FreeBSD or `ip route` on Linux.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"netutil/routetable"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "monitor" {
+		runMonitor()
+		return
+	}
+
+	entries, err := routetable.Get(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 10, 2, 2, ' ', 0)
+	defer writer.Flush()
+
+	writer.Write([]byte("Family\tDestination\tGateway\tNetif\tFlags\n"))
+	for _, e := range entries {
+		dump(e, writer)
+	}
+}
+
+func dump(e routetable.RouteEntry, writer *tabwriter.Writer) {
+	destination := "default"
+	if e.Dst.Bits() != 0 {
+		destination = e.Dst.String()
+	}
+
+	gateway := ""
+	switch {
+	case e.Gateway.IsValid():
+		gateway = e.Gateway.String()
+	case e.GatewayInterface != "":
+		gateway = e.GatewayInterface
+	case e.GatewayLinkAddr != "":
+		gateway = e.GatewayLinkAddr
+	}
+
+	fmt.Fprintf(writer, "%v\t%v\t%v\t%v\t%v\n",
+		familyName(e.Family),
+		destination,
+		gateway,
+		e.Interface,
+		strings.Join(e.Flags, ","),
+	)
+}
+
+func familyName(family int) string {
+	switch family {
+	case syscall.AF_INET:
+		return "inet"
+	case syscall.AF_INET6:
+		return "inet6"
+	default:
+		return fmt.Sprintf("%d", family)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+
+	"netutil/routetable"
+)
+
+// runMonitor implements the `route monitor` subcommand: it streams route
+// and interface changes until interrupted, pretty-printing each one.
+func runMonitor() {
+	mon, err := routetable.NewMonitor()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer mon.Close()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		<-sig
+		mon.Close()
+	}()
+
+	for e := range mon.Events() {
+		fmt.Println(formatEvent(e))
+	}
+}
+
+func formatEvent(e routetable.Event) string {
+	types := strings.Join(routetable.TypeNames(e.RawType), ",")
+	flags := strings.Join(routetable.FlagNames(e.RawFlags), ",")
+
+	switch e.Type {
+	case routetable.RouteAdded, routetable.RouteDeleted, routetable.RouteChanged:
+		return fmt.Sprintf("%s: dst=%v gw=%v netif=%s type=%s flags=%s",
+			e.Type, e.Route.Dst, e.Route.Gateway, e.Route.Interface, types, flags)
+	case routetable.AddrAdded, routetable.AddrRemoved:
+		return fmt.Sprintf("%s: netif=%s addr=%v type=%s flags=%s", e.Type, e.Interface, e.Addr, types, flags)
+	default:
+		return fmt.Sprintf("%s: netif=%s type=%s flags=%s", e.Type, e.Interface, types, flags)
+	}
+}
@@ -0,0 +1,65 @@
+package routetable
+
+import "net/netip"
+
+// EventType identifies the kind of change a Monitor delivers.
+type EventType int
+
+const (
+	RouteAdded EventType = iota
+	RouteDeleted
+	RouteChanged
+	InterfaceUp
+	InterfaceDown
+	AddrAdded
+	AddrRemoved
+)
+
+func (t EventType) String() string {
+	switch t {
+	case RouteAdded:
+		return "RouteAdded"
+	case RouteDeleted:
+		return "RouteDeleted"
+	case RouteChanged:
+		return "RouteChanged"
+	case InterfaceUp:
+		return "InterfaceUp"
+	case InterfaceDown:
+		return "InterfaceDown"
+	case AddrAdded:
+		return "AddrAdded"
+	case AddrRemoved:
+		return "AddrRemoved"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is a single routing-table or interface change delivered by a
+// Monitor. Only the fields relevant to Type are populated: Route for the
+// Route* events, Interface and Addr for the Addr* events, and just
+// Interface for the Interface* events.
+type Event struct {
+	Type      EventType
+	Route     *RouteEntry
+	Interface string
+	Addr      netip.Prefix
+
+	// RawType and RawFlags are the undecoded platform-specific message
+	// type and flags the event was decoded from.
+	RawType  int
+	RawFlags int
+}
+
+// FlagNames decodes a platform-specific route flags bitmask into its
+// symbolic names, e.g. "RTF_UP", "RTF_GATEWAY".
+func FlagNames(flags int) []string {
+	return flagNames(flags)
+}
+
+// TypeNames decodes a platform-specific route/netlink message type
+// into its symbolic names, e.g. "RTM_ADD", "RTM_NEWROUTE".
+func TypeNames(t int) []string {
+	return typeNames(t)
+}
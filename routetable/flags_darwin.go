@@ -0,0 +1,160 @@
+package routetable
+
+import "syscall"
+
+// shouldSkip reports whether a route message is a bare interface reference
+// entry (gateway + host + ifref) rather than a real route, and should be
+// left out of the dump.
+func shouldSkip(flags int) bool {
+	return flags&syscall.RTF_GATEWAY == syscall.RTF_GATEWAY &&
+		flags&syscall.RTF_HOST == syscall.RTF_HOST &&
+		flags&syscall.RTF_IFREF == syscall.RTF_IFREF
+}
+
+func flagNames(t int) []string {
+	ret := []string{}
+	if t&syscall.RTF_BLACKHOLE == syscall.RTF_BLACKHOLE {
+		ret = append(ret, "RTF_BLACKHOLE")
+	}
+	if t&syscall.RTF_BROADCAST == syscall.RTF_BROADCAST {
+		ret = append(ret, "RTF_BROADCAST")
+	}
+	if t&syscall.RTF_CLONING == syscall.RTF_CLONING {
+		ret = append(ret, "RTF_CLONING")
+	}
+	if t&syscall.RTF_CONDEMNED == syscall.RTF_CONDEMNED {
+		ret = append(ret, "RTF_CONDEMNED")
+	}
+	if t&syscall.RTF_DELCLONE == syscall.RTF_DELCLONE {
+		ret = append(ret, "RTF_DELCLONE")
+	}
+	if t&syscall.RTF_DONE == syscall.RTF_DONE {
+		ret = append(ret, "RTF_DONE")
+	}
+	if t&syscall.RTF_DYNAMIC == syscall.RTF_DYNAMIC {
+		ret = append(ret, "RTF_DYNAMIC")
+	}
+	if t&syscall.RTF_GATEWAY == syscall.RTF_GATEWAY {
+		ret = append(ret, "RTF_GATEWAY")
+	}
+	if t&syscall.RTF_HOST == syscall.RTF_HOST {
+		ret = append(ret, "RTF_HOST")
+	}
+	if t&syscall.RTF_IFREF == syscall.RTF_IFREF {
+		ret = append(ret, "RTF_IFREF")
+	}
+	if t&syscall.RTF_IFSCOPE == syscall.RTF_IFSCOPE {
+		ret = append(ret, "RTF_IFSCOPE")
+	}
+	if t&syscall.RTF_LLINFO == syscall.RTF_LLINFO {
+		ret = append(ret, "RTF_LLINFO")
+	}
+	if t&syscall.RTF_LOCAL == syscall.RTF_LOCAL {
+		ret = append(ret, "RTF_LOCAL")
+	}
+	if t&syscall.RTF_MODIFIED == syscall.RTF_MODIFIED {
+		ret = append(ret, "RTF_MODIFIED")
+	}
+	if t&syscall.RTF_MULTICAST == syscall.RTF_MULTICAST {
+		ret = append(ret, "RTF_MULTICAST")
+	}
+	if t&syscall.RTF_PINNED == syscall.RTF_PINNED {
+		ret = append(ret, "RTF_PINNED")
+	}
+	if t&syscall.RTF_PRCLONING == syscall.RTF_PRCLONING {
+		ret = append(ret, "RTF_PRCLONING")
+	}
+	if t&syscall.RTF_PROTO1 == syscall.RTF_PROTO1 {
+		ret = append(ret, "RTF_PROTO1")
+	}
+	if t&syscall.RTF_PROTO2 == syscall.RTF_PROTO2 {
+		ret = append(ret, "RTF_PROTO2")
+	}
+	if t&syscall.RTF_PROTO3 == syscall.RTF_PROTO3 {
+		ret = append(ret, "RTF_PROTO3")
+	}
+	if t&syscall.RTF_REJECT == syscall.RTF_REJECT {
+		ret = append(ret, "RTF_REJECT")
+	}
+	if t&syscall.RTF_STATIC == syscall.RTF_STATIC {
+		ret = append(ret, "RTF_STATIC")
+	}
+	if t&syscall.RTF_UP == syscall.RTF_UP {
+		ret = append(ret, "RTF_UP")
+	}
+	if t&syscall.RTF_WASCLONED == syscall.RTF_WASCLONED {
+		ret = append(ret, "RTF_WASCLONED")
+	}
+	if t&syscall.RTF_XRESOLVE == syscall.RTF_XRESOLVE {
+		ret = append(ret, "RTF_XRESOLVE")
+	}
+	return ret
+}
+
+func typeNames(t int) []string {
+	ret := []string{}
+	if t&syscall.RTM_ADD == syscall.RTM_ADD {
+		ret = append(ret, "RTM_ADD")
+	}
+	if t&syscall.RTM_CHANGE == syscall.RTM_CHANGE {
+		ret = append(ret, "RTM_CHANGE")
+	}
+	if t&syscall.RTM_DELADDR == syscall.RTM_DELADDR {
+		ret = append(ret, "RTM_DELADDR")
+	}
+	if t&syscall.RTM_DELETE == syscall.RTM_DELETE {
+		ret = append(ret, "RTM_DELETE")
+	}
+	if t&syscall.RTM_DELMADDR == syscall.RTM_DELMADDR {
+		ret = append(ret, "RTM_DELMADDR")
+	}
+	if t&syscall.RTM_GET == syscall.RTM_GET {
+		ret = append(ret, "RTM_GET")
+	}
+	if t&syscall.RTM_GET2 == syscall.RTM_GET2 {
+		ret = append(ret, "RTM_GET2")
+	}
+	if t&syscall.RTM_IFINFO == syscall.RTM_IFINFO {
+		ret = append(ret, "RTM_IFINFO")
+	}
+	if t&syscall.RTM_IFINFO2 == syscall.RTM_IFINFO2 {
+		ret = append(ret, "RTM_IFINFO2")
+	}
+	if t&syscall.RTM_LOCK == syscall.RTM_LOCK {
+		ret = append(ret, "RTM_LOCK")
+	}
+	if t&syscall.RTM_LOSING == syscall.RTM_LOSING {
+		ret = append(ret, "RTM_LOSING")
+	}
+	if t&syscall.RTM_MISS == syscall.RTM_MISS {
+		ret = append(ret, "RTM_MISS")
+	}
+	if t&syscall.RTM_NEWADDR == syscall.RTM_NEWADDR {
+		ret = append(ret, "RTM_NEWADDR")
+	}
+	if t&syscall.RTM_NEWMADDR == syscall.RTM_NEWMADDR {
+		ret = append(ret, "RTM_NEWMADDR")
+	}
+	if t&syscall.RTM_NEWMADDR2 == syscall.RTM_NEWMADDR2 {
+		ret = append(ret, "RTM_NEWMADDR2")
+	}
+	if t&syscall.RTM_OLDADD == syscall.RTM_OLDADD {
+		ret = append(ret, "RTM_OLDADD")
+	}
+	if t&syscall.RTM_OLDDEL == syscall.RTM_OLDDEL {
+		ret = append(ret, "RTM_OLDDEL")
+	}
+	if t&syscall.RTM_REDIRECT == syscall.RTM_REDIRECT {
+		ret = append(ret, "RTM_REDIRECT")
+	}
+	if t&syscall.RTM_RESOLVE == syscall.RTM_RESOLVE {
+		ret = append(ret, "RTM_RESOLVE")
+	}
+	if t&syscall.RTM_RTTUNIT == syscall.RTM_RTTUNIT {
+		ret = append(ret, "RTM_RTTUNIT")
+	}
+	if t&syscall.RTM_VERSION == syscall.RTM_VERSION {
+		ret = append(ret, "RTM_VERSION")
+	}
+	return ret
+}
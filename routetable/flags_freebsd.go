@@ -0,0 +1,105 @@
+package routetable
+
+import "syscall"
+
+// shouldSkip reports whether a route message has both RTF_GATEWAY and
+// RTF_HOST set, and should be left out of the dump. FreeBSD has no
+// RTF_IFREF, so every host route via a gateway is skipped.
+func shouldSkip(flags int) bool {
+	return flags&syscall.RTF_GATEWAY == syscall.RTF_GATEWAY &&
+		flags&syscall.RTF_HOST == syscall.RTF_HOST
+}
+
+func flagNames(t int) []string {
+	ret := []string{}
+	if t&syscall.RTF_BLACKHOLE == syscall.RTF_BLACKHOLE {
+		ret = append(ret, "RTF_BLACKHOLE")
+	}
+	if t&syscall.RTF_BROADCAST == syscall.RTF_BROADCAST {
+		ret = append(ret, "RTF_BROADCAST")
+	}
+	if t&syscall.RTF_DONE == syscall.RTF_DONE {
+		ret = append(ret, "RTF_DONE")
+	}
+	if t&syscall.RTF_DYNAMIC == syscall.RTF_DYNAMIC {
+		ret = append(ret, "RTF_DYNAMIC")
+	}
+	if t&syscall.RTF_GATEWAY == syscall.RTF_GATEWAY {
+		ret = append(ret, "RTF_GATEWAY")
+	}
+	if t&syscall.RTF_HOST == syscall.RTF_HOST {
+		ret = append(ret, "RTF_HOST")
+	}
+	if t&syscall.RTF_LLINFO == syscall.RTF_LLINFO {
+		ret = append(ret, "RTF_LLINFO")
+	}
+	if t&syscall.RTF_MODIFIED == syscall.RTF_MODIFIED {
+		ret = append(ret, "RTF_MODIFIED")
+	}
+	if t&syscall.RTF_MULTICAST == syscall.RTF_MULTICAST {
+		ret = append(ret, "RTF_MULTICAST")
+	}
+	if t&syscall.RTF_PROTO1 == syscall.RTF_PROTO1 {
+		ret = append(ret, "RTF_PROTO1")
+	}
+	if t&syscall.RTF_PROTO2 == syscall.RTF_PROTO2 {
+		ret = append(ret, "RTF_PROTO2")
+	}
+	if t&syscall.RTF_PROTO3 == syscall.RTF_PROTO3 {
+		ret = append(ret, "RTF_PROTO3")
+	}
+	if t&syscall.RTF_REJECT == syscall.RTF_REJECT {
+		ret = append(ret, "RTF_REJECT")
+	}
+	if t&syscall.RTF_STATIC == syscall.RTF_STATIC {
+		ret = append(ret, "RTF_STATIC")
+	}
+	if t&syscall.RTF_UP == syscall.RTF_UP {
+		ret = append(ret, "RTF_UP")
+	}
+	if t&syscall.RTF_XRESOLVE == syscall.RTF_XRESOLVE {
+		ret = append(ret, "RTF_XRESOLVE")
+	}
+	return ret
+}
+
+func typeNames(t int) []string {
+	ret := []string{}
+	if t&syscall.RTM_ADD == syscall.RTM_ADD {
+		ret = append(ret, "RTM_ADD")
+	}
+	if t&syscall.RTM_CHANGE == syscall.RTM_CHANGE {
+		ret = append(ret, "RTM_CHANGE")
+	}
+	if t&syscall.RTM_DELADDR == syscall.RTM_DELADDR {
+		ret = append(ret, "RTM_DELADDR")
+	}
+	if t&syscall.RTM_DELETE == syscall.RTM_DELETE {
+		ret = append(ret, "RTM_DELETE")
+	}
+	if t&syscall.RTM_GET == syscall.RTM_GET {
+		ret = append(ret, "RTM_GET")
+	}
+	if t&syscall.RTM_IFINFO == syscall.RTM_IFINFO {
+		ret = append(ret, "RTM_IFINFO")
+	}
+	if t&syscall.RTM_LOSING == syscall.RTM_LOSING {
+		ret = append(ret, "RTM_LOSING")
+	}
+	if t&syscall.RTM_MISS == syscall.RTM_MISS {
+		ret = append(ret, "RTM_MISS")
+	}
+	if t&syscall.RTM_NEWADDR == syscall.RTM_NEWADDR {
+		ret = append(ret, "RTM_NEWADDR")
+	}
+	if t&syscall.RTM_REDIRECT == syscall.RTM_REDIRECT {
+		ret = append(ret, "RTM_REDIRECT")
+	}
+	if t&syscall.RTM_RESOLVE == syscall.RTM_RESOLVE {
+		ret = append(ret, "RTM_RESOLVE")
+	}
+	if t&syscall.RTM_VERSION == syscall.RTM_VERSION {
+		ret = append(ret, "RTM_VERSION")
+	}
+	return ret
+}
@@ -0,0 +1,50 @@
+package routetable
+
+import "syscall"
+
+func flagNames(t int) []string {
+	ret := []string{}
+	if t&syscall.RTF_UP == syscall.RTF_UP {
+		ret = append(ret, "RTF_UP")
+	}
+	if t&syscall.RTF_GATEWAY == syscall.RTF_GATEWAY {
+		ret = append(ret, "RTF_GATEWAY")
+	}
+	if t&syscall.RTF_HOST == syscall.RTF_HOST {
+		ret = append(ret, "RTF_HOST")
+	}
+	if t&syscall.RTF_DYNAMIC == syscall.RTF_DYNAMIC {
+		ret = append(ret, "RTF_DYNAMIC")
+	}
+	if t&syscall.RTF_MODIFIED == syscall.RTF_MODIFIED {
+		ret = append(ret, "RTF_MODIFIED")
+	}
+	if t&syscall.RTF_REJECT == syscall.RTF_REJECT {
+		ret = append(ret, "RTF_REJECT")
+	}
+	return ret
+}
+
+// typeNames decodes a linux RTM_* message type. Linux's RTM_* values are
+// sequential enum values, not independent bits, so this switches on
+// equality rather than masking.
+func typeNames(t int) []string {
+	switch t {
+	case syscall.RTM_NEWROUTE:
+		return []string{"RTM_NEWROUTE"}
+	case syscall.RTM_DELROUTE:
+		return []string{"RTM_DELROUTE"}
+	case syscall.RTM_GETROUTE:
+		return []string{"RTM_GETROUTE"}
+	case syscall.RTM_NEWLINK:
+		return []string{"RTM_NEWLINK"}
+	case syscall.RTM_DELLINK:
+		return []string{"RTM_DELLINK"}
+	case syscall.RTM_NEWADDR:
+		return []string{"RTM_NEWADDR"}
+	case syscall.RTM_DELADDR:
+		return []string{"RTM_DELADDR"}
+	default:
+		return []string{}
+	}
+}
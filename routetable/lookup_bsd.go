@@ -0,0 +1,127 @@
+//go:build darwin || freebsd
+
+package routetable
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"syscall"
+
+	"golang.org/x/net/route"
+)
+
+// rtax indices into a RouteMessage's Addrs slice, matching the kernel's
+// rtm_addrs bitmask ordering.
+const (
+	rtaxDst = iota
+	rtaxGateway
+	rtaxNetmask
+	rtaxGenmask
+	rtaxIfp
+	rtaxIfa
+)
+
+// LookupRoute asks the kernel which route would be used to reach dst,
+// equivalent to `route -n get <ip>`.
+//
+// x/net/route doesn't surface the kernel's rt_metrics, so the returned
+// RouteEntry's MTU is always left unset on this platform.
+func LookupRoute(dst netip.Addr) (RouteEntry, error) {
+	fd, err := syscall.Socket(syscall.AF_ROUTE, syscall.SOCK_RAW, syscall.AF_UNSPEC)
+	if err != nil {
+		return RouteEntry{}, fmt.Errorf("open routing socket: %w", err)
+	}
+	defer syscall.Close(fd)
+
+	msg := &route.RouteMessage{
+		Version: syscall.RTM_VERSION,
+		Type:    syscall.RTM_GET,
+		Seq:     os.Getpid(),
+		Addrs:   []route.Addr{toRouteAddr(dst)},
+	}
+	b, err := msg.Marshal()
+	if err != nil {
+		return RouteEntry{}, fmt.Errorf("marshal route message: %w", err)
+	}
+	if _, err := syscall.Write(fd, b); err != nil {
+		return RouteEntry{}, fmt.Errorf("write route message: %w", err)
+	}
+
+	return readLookupReply(fd, msg.Seq)
+}
+
+func readLookupReply(fd, seq int) (RouteEntry, error) {
+	buf := make([]byte, os.Getpagesize())
+	for {
+		n, err := syscall.Read(fd, buf)
+		if err != nil {
+			return RouteEntry{}, fmt.Errorf("read route reply: %w", err)
+		}
+		msgs, err := route.ParseRIB(route.RIBTypeRoute, buf[:n])
+		if err != nil {
+			return RouteEntry{}, fmt.Errorf("parse route reply: %w", err)
+		}
+		for _, m := range msgs {
+			rm, ok := m.(*route.RouteMessage)
+			if !ok || rm.Seq != seq {
+				continue
+			}
+			if rm.Err != nil {
+				return RouteEntry{}, rm.Err
+			}
+			return toLookupEntry(rm), nil
+		}
+	}
+}
+
+func toLookupEntry(rm *route.RouteMessage) RouteEntry {
+	e := RouteEntry{
+		Flags:    flagNames(rm.Flags),
+		RawFlags: rm.Flags,
+		Type:     rm.Type,
+	}
+	if rm.Index != 0 {
+		if intf, err := net.InterfaceByIndex(rm.Index); err == nil {
+			e.Interface = intf.Name
+		}
+	}
+
+	if a, ok := addrAt(rm.Addrs, rtaxDst); ok {
+		e.Family = familyOf(a)
+		bits := a.BitLen()
+		if m, ok := addrAt(rm.Addrs, rtaxNetmask); ok {
+			bits, _ = net.IPMask(m.AsSlice()).Size()
+		}
+		e.Dst = netip.PrefixFrom(a, bits)
+	}
+	if a, ok := addrAt(rm.Addrs, rtaxGateway); ok {
+		e.Gateway = a
+	}
+	if a, ok := addrAt(rm.Addrs, rtaxIfa); ok {
+		e.Src = a
+	}
+	return e
+}
+
+func addrAt(addrs []route.Addr, i int) (netip.Addr, bool) {
+	if i >= len(addrs) || addrs[i] == nil {
+		return netip.Addr{}, false
+	}
+	switch a := addrs[i].(type) {
+	case *route.Inet4Addr:
+		return netip.AddrFrom4(a.IP), true
+	case *route.Inet6Addr:
+		return inet6Addr(a), true
+	default:
+		return netip.Addr{}, false
+	}
+}
+
+func familyOf(a netip.Addr) int {
+	if a.Is4() {
+		return syscall.AF_INET
+	}
+	return syscall.AF_INET6
+}
@@ -0,0 +1,111 @@
+package routetable
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// LookupRoute asks the kernel which route would be used to reach dst,
+// equivalent to `ip route get <ip>`.
+func LookupRoute(dst netip.Addr) (RouteEntry, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW, syscall.NETLINK_ROUTE)
+	if err != nil {
+		return RouteEntry{}, fmt.Errorf("open netlink socket: %w", err)
+	}
+	defer syscall.Close(fd)
+
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return RouteEntry{}, fmt.Errorf("bind netlink socket: %w", err)
+	}
+
+	family, bits := syscall.AF_INET, 32
+	if dst.Is6() {
+		family, bits = syscall.AF_INET6, 128
+	}
+
+	req := newNetlinkRtRequest(syscall.RTM_GETROUTE, syscall.NLM_F_REQUEST, family, bits, 0, syscall.RTN_UNICAST)
+	req = appendRouteAttr(req, syscall.RTA_DST, dst.AsSlice())
+
+	if err := syscall.Sendto(fd, req, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return RouteEntry{}, fmt.Errorf("send netlink request: %w", err)
+	}
+
+	buf := make([]byte, os.Getpagesize())
+	n, err := syscall.Read(fd, buf)
+	if err != nil {
+		return RouteEntry{}, fmt.Errorf("read netlink reply: %w", err)
+	}
+	msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+	if err != nil {
+		return RouteEntry{}, fmt.Errorf("parse netlink reply: %w", err)
+	}
+	for _, m := range msgs {
+		switch m.Header.Type {
+		case syscall.NLMSG_ERROR:
+			if errno := int32(binary.NativeEndian.Uint32(m.Data[:4])); errno != 0 {
+				return RouteEntry{}, syscall.Errno(-errno)
+			}
+		case syscall.RTM_NEWROUTE:
+			return toLookupEntry(m)
+		}
+	}
+	return RouteEntry{}, fmt.Errorf("no route reply received for %v", dst)
+}
+
+// toLookupEntry decodes an RTM_NEWROUTE reply to an RTM_GETROUTE query
+// into a RouteEntry, adding the source-address hint and MTU. Unlike
+// toRouteEntry (used by Get/Monitor), it doesn't filter by routing
+// table: the kernel correctly answers a route-get for a local address
+// with a route from RT_TABLE_LOCAL, not RT_TABLE_MAIN, and that's still
+// a valid answer here.
+func toLookupEntry(msg syscall.NetlinkMessage) (RouteEntry, error) {
+	rtmsg, err := rtMsgFromNetlink(msg)
+	if err != nil {
+		return RouteEntry{}, err
+	}
+	e, err := decodeRouteEntry(msg, rtmsg)
+	if err != nil {
+		return RouteEntry{}, err
+	}
+
+	attrs, err := syscall.ParseNetlinkRouteAttr(&msg)
+	if err != nil {
+		return RouteEntry{}, fmt.Errorf("parse route attrs: %w", err)
+	}
+	for _, a := range attrs {
+		switch a.Attr.Type {
+		case syscall.RTA_PREFSRC:
+			if src, err := addrFromBytes(int(rtmsg.Family), a.Value); err == nil {
+				e.Src = src
+			}
+		case syscall.RTA_METRICS:
+			e.MTU = mtuFromMetrics(a.Value)
+		}
+	}
+	return e, nil
+}
+
+// mtuFromMetrics walks the nested RTA_METRICS attribute looking for
+// RTAX_MTU.
+func mtuFromMetrics(b []byte) int {
+	for len(b) >= syscall.SizeofRtAttr {
+		rta := (*syscall.RtAttr)(unsafe.Pointer(&b[0]))
+		l := int(rta.Len)
+		if l < syscall.SizeofRtAttr || l > len(b) {
+			return 0
+		}
+		if rta.Type == syscall.RTAX_MTU && l >= syscall.SizeofRtAttr+4 {
+			return int(binary.NativeEndian.Uint32(b[syscall.SizeofRtAttr : syscall.SizeofRtAttr+4]))
+		}
+		pad := (l + 3) &^ 3
+		if pad == 0 {
+			return 0
+		}
+		b = b[pad:]
+	}
+	return 0
+}
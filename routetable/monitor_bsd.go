@@ -0,0 +1,149 @@
+//go:build darwin || freebsd
+
+package routetable
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"sync"
+	"syscall"
+
+	"golang.org/x/net/route"
+)
+
+// Monitor streams kernel routing table and interface changes.
+type Monitor struct {
+	fd        int
+	events    chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMonitor opens a PF_ROUTE socket and starts delivering route and
+// interface change events on the channel returned by Events.
+func NewMonitor() (*Monitor, error) {
+	fd, err := syscall.Socket(syscall.AF_ROUTE, syscall.SOCK_RAW, syscall.AF_UNSPEC)
+	if err != nil {
+		return nil, fmt.Errorf("open routing socket: %w", err)
+	}
+	m := &Monitor{
+		fd:     fd,
+		events: make(chan Event),
+		done:   make(chan struct{}),
+	}
+	go m.loop()
+	return m, nil
+}
+
+// Events returns the channel Monitor delivers events on. It's closed
+// once Close is called and the read loop has drained.
+func (m *Monitor) Events() <-chan Event {
+	return m.events
+}
+
+// Close stops the monitor, unblocking the read loop via shutdown(2).
+func (m *Monitor) Close() error {
+	var err error
+	m.closeOnce.Do(func() {
+		syscall.Shutdown(m.fd, syscall.SHUT_RDWR)
+		err = syscall.Close(m.fd)
+		close(m.done)
+	})
+	return err
+}
+
+func (m *Monitor) loop() {
+	defer close(m.events)
+
+	buf := make([]byte, os.Getpagesize())
+	for {
+		n, err := syscall.Read(m.fd, buf)
+		if err != nil {
+			return
+		}
+		msgs, err := route.ParseRIB(route.RIBTypeRoute, buf[:n])
+		if err != nil {
+			continue
+		}
+		for _, msg := range msgs {
+			e, ok := toEvent(msg)
+			if !ok {
+				continue
+			}
+			select {
+			case m.events <- e:
+			case <-m.done:
+				return
+			}
+		}
+	}
+}
+
+func toEvent(msg route.Message) (Event, bool) {
+	switch rm := msg.(type) {
+	case *route.RouteMessage:
+		return routeEvent(rm)
+	case *route.InterfaceMessage:
+		return interfaceEvent(rm)
+	case *route.InterfaceAddrMessage:
+		return addrEvent(rm)
+	default:
+		return Event{}, false
+	}
+}
+
+func routeEvent(rm *route.RouteMessage) (Event, bool) {
+	var typ EventType
+	switch rm.Type {
+	case syscall.RTM_ADD:
+		typ = RouteAdded
+	case syscall.RTM_DELETE:
+		typ = RouteDeleted
+	case syscall.RTM_CHANGE:
+		typ = RouteChanged
+	default:
+		return Event{}, false
+	}
+	e, ok, err := toRouteEntry(rm)
+	if err != nil || !ok {
+		return Event{}, false
+	}
+	return Event{Type: typ, Route: &e, RawType: rm.Type, RawFlags: rm.Flags}, true
+}
+
+func interfaceEvent(im *route.InterfaceMessage) (Event, bool) {
+	intf, err := net.InterfaceByIndex(im.Index)
+	if err != nil {
+		return Event{}, false
+	}
+	typ := InterfaceDown
+	if im.Flags&syscall.IFF_UP == syscall.IFF_UP {
+		typ = InterfaceUp
+	}
+	return Event{Type: typ, Interface: intf.Name, RawType: im.Type, RawFlags: im.Flags}, true
+}
+
+func addrEvent(am *route.InterfaceAddrMessage) (Event, bool) {
+	intf, err := net.InterfaceByIndex(am.Index)
+	if err != nil {
+		return Event{}, false
+	}
+	addr, ok := addrAt(am.Addrs, rtaxIfa)
+	if !ok {
+		return Event{}, false
+	}
+
+	typ := AddrAdded
+	if am.Type == syscall.RTM_DELADDR {
+		typ = AddrRemoved
+	}
+	return Event{
+		Type:      typ,
+		Interface: intf.Name,
+		Addr:      netip.PrefixFrom(addr, addr.BitLen()),
+		RawType:   am.Type,
+		RawFlags:  am.Flags,
+	}, true
+}
@@ -0,0 +1,182 @@
+package routetable
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Monitor streams kernel routing table and link changes.
+type Monitor struct {
+	fd        int
+	events    chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMonitor opens a netlink socket subscribed to route and link change
+// groups and starts delivering events on the channel returned by Events.
+func NewMonitor() (*Monitor, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW, syscall.NETLINK_ROUTE)
+	if err != nil {
+		return nil, fmt.Errorf("open netlink socket: %w", err)
+	}
+
+	groups := uint32(unix.RTMGRP_IPV4_ROUTE | unix.RTMGRP_IPV6_ROUTE | unix.RTMGRP_LINK |
+		unix.RTMGRP_IPV4_IFADDR | unix.RTMGRP_IPV6_IFADDR)
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: groups}); err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("bind netlink socket: %w", err)
+	}
+
+	m := &Monitor{
+		fd:     fd,
+		events: make(chan Event),
+		done:   make(chan struct{}),
+	}
+	go m.loop()
+	return m, nil
+}
+
+// Events returns the channel Monitor delivers events on. It's closed
+// once Close is called and the read loop has drained.
+func (m *Monitor) Events() <-chan Event {
+	return m.events
+}
+
+// Close stops the monitor, unblocking the read loop via shutdown(2).
+func (m *Monitor) Close() error {
+	var err error
+	m.closeOnce.Do(func() {
+		syscall.Shutdown(m.fd, syscall.SHUT_RDWR)
+		err = syscall.Close(m.fd)
+		close(m.done)
+	})
+	return err
+}
+
+func (m *Monitor) loop() {
+	defer close(m.events)
+
+	buf := make([]byte, os.Getpagesize())
+	for {
+		n, err := syscall.Read(m.fd, buf)
+		if err != nil {
+			return
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			continue
+		}
+		for _, msg := range msgs {
+			e, ok := toMonitorEvent(msg)
+			if !ok {
+				continue
+			}
+			select {
+			case m.events <- e:
+			case <-m.done:
+				return
+			}
+		}
+	}
+}
+
+func toMonitorEvent(msg syscall.NetlinkMessage) (Event, bool) {
+	switch msg.Header.Type {
+	case syscall.RTM_NEWROUTE, syscall.RTM_DELROUTE:
+		return routeEvent(msg)
+	case syscall.RTM_NEWLINK, syscall.RTM_DELLINK:
+		return linkEvent(msg)
+	case syscall.RTM_NEWADDR, syscall.RTM_DELADDR:
+		return addrEvent(msg)
+	default:
+		return Event{}, false
+	}
+}
+
+func routeEvent(msg syscall.NetlinkMessage) (Event, bool) {
+	e, ok, err := toRouteEntry(msg)
+	if err != nil || !ok {
+		return Event{}, false
+	}
+	typ := RouteAdded
+	switch {
+	case msg.Header.Type == syscall.RTM_DELROUTE:
+		typ = RouteDeleted
+	case msg.Header.Flags&syscall.NLM_F_REPLACE == syscall.NLM_F_REPLACE:
+		typ = RouteChanged
+	}
+	return Event{Type: typ, Route: &e, RawType: int(msg.Header.Type), RawFlags: e.RawFlags}, true
+}
+
+func addrEvent(msg syscall.NetlinkMessage) (Event, bool) {
+	if len(msg.Data) < syscall.SizeofIfAddrmsg {
+		return Event{}, false
+	}
+	ifam := (*syscall.IfAddrmsg)(unsafe.Pointer(&msg.Data[0]))
+
+	intf, err := net.InterfaceByIndex(int(ifam.Index))
+	if err != nil {
+		return Event{}, false
+	}
+
+	attrs, err := syscall.ParseNetlinkRouteAttr(&msg)
+	if err != nil {
+		return Event{}, false
+	}
+	var addr netip.Addr
+	for _, a := range attrs {
+		if a.Attr.Type != syscall.IFA_ADDRESS {
+			continue
+		}
+		if a, err := addrFromBytes(int(ifam.Family), a.Value); err == nil {
+			addr = a
+		}
+		break
+	}
+	if !addr.IsValid() {
+		return Event{}, false
+	}
+
+	typ := AddrAdded
+	if msg.Header.Type == syscall.RTM_DELADDR {
+		typ = AddrRemoved
+	}
+	return Event{
+		Type:      typ,
+		Interface: intf.Name,
+		Addr:      netip.PrefixFrom(addr, int(ifam.Prefixlen)),
+		RawType:   int(msg.Header.Type),
+		RawFlags:  int(ifam.Flags),
+	}, true
+}
+
+func linkEvent(msg syscall.NetlinkMessage) (Event, bool) {
+	if len(msg.Data) < syscall.SizeofIfInfomsg {
+		return Event{}, false
+	}
+	ifim := (*syscall.IfInfomsg)(unsafe.Pointer(&msg.Data[0]))
+
+	intf, err := net.InterfaceByIndex(int(ifim.Index))
+	if err != nil {
+		return Event{}, false
+	}
+
+	typ := InterfaceDown
+	if ifim.Flags&syscall.IFF_UP == syscall.IFF_UP {
+		typ = InterfaceUp
+	}
+	return Event{
+		Type:      typ,
+		Interface: intf.Name,
+		RawType:   int(msg.Header.Type),
+		RawFlags:  int(ifim.Flags),
+	}, true
+}
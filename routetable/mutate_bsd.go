@@ -0,0 +1,120 @@
+//go:build darwin || freebsd
+
+package routetable
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"syscall"
+
+	"golang.org/x/net/route"
+)
+
+// DefaultRouteFlags is the flag set used by RouteAdd/RouteChange when the
+// caller passes 0, matching what `route add` sets for a gateway route.
+const DefaultRouteFlags = syscall.RTF_UP | syscall.RTF_GATEWAY | syscall.RTF_STATIC
+
+// RouteAdd installs a new route to dst via gw.
+func RouteAdd(dst netip.Prefix, gw netip.Addr, flags int) error {
+	return writeRouteMessage(syscall.RTM_ADD, dst, gw, flags)
+}
+
+// RouteDelete removes the route to dst via gw.
+func RouteDelete(dst netip.Prefix, gw netip.Addr, flags int) error {
+	return writeRouteMessage(syscall.RTM_DELETE, dst, gw, flags)
+}
+
+// RouteChange updates the existing route to dst to go via gw.
+func RouteChange(dst netip.Prefix, gw netip.Addr, flags int) error {
+	return writeRouteMessage(syscall.RTM_CHANGE, dst, gw, flags)
+}
+
+func writeRouteMessage(typ int, dst netip.Prefix, gw netip.Addr, flags int) error {
+	if flags == 0 {
+		flags = DefaultRouteFlags
+	}
+	if flags&RouteFlagBlackhole != 0 {
+		flags = flags&^RouteFlagBlackhole | syscall.RTF_BLACKHOLE
+	}
+
+	fd, err := syscall.Socket(syscall.AF_ROUTE, syscall.SOCK_RAW, syscall.AF_UNSPEC)
+	if err != nil {
+		return fmt.Errorf("open routing socket: %w", err)
+	}
+	defer syscall.Close(fd)
+
+	msg := &route.RouteMessage{
+		Version: syscall.RTM_VERSION,
+		Type:    typ,
+		Flags:   flags,
+		Seq:     os.Getpid(),
+		Addrs: []route.Addr{
+			toRouteAddr(dst.Addr()),
+			toRouteAddr(gw),
+			netmaskAddr(dst),
+		},
+	}
+
+	b, err := msg.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshal route message: %w", err)
+	}
+	if _, err := syscall.Write(fd, b); err != nil {
+		return fmt.Errorf("write route message: %w", err)
+	}
+
+	return readRouteReply(fd, msg.Seq)
+}
+
+// readRouteReply reads replies off the routing socket until it sees the
+// one matching our seq, then reports the kernel's errno for it.
+func readRouteReply(fd, seq int) error {
+	buf := make([]byte, os.Getpagesize())
+	for {
+		n, err := syscall.Read(fd, buf)
+		if err != nil {
+			return fmt.Errorf("read route reply: %w", err)
+		}
+		msgs, err := route.ParseRIB(route.RIBTypeRoute, buf[:n])
+		if err != nil {
+			return fmt.Errorf("parse route reply: %w", err)
+		}
+		for _, m := range msgs {
+			rm, ok := m.(*route.RouteMessage)
+			if !ok || rm.Seq != seq {
+				continue
+			}
+			if rm.Err != nil {
+				return rm.Err
+			}
+			return nil
+		}
+	}
+}
+
+func toRouteAddr(a netip.Addr) route.Addr {
+	if a.Is4() {
+		return &route.Inet4Addr{IP: a.As4()}
+	}
+	return &route.Inet6Addr{IP: a.As16()}
+}
+
+func netmaskAddr(p netip.Prefix) route.Addr {
+	if p.Addr().Is4() {
+		mask := [4]byte{}
+		copy(mask[:], cidrMask(p.Bits(), 32))
+		return &route.Inet4Addr{IP: mask}
+	}
+	mask := [16]byte{}
+	copy(mask[:], cidrMask(p.Bits(), 128))
+	return &route.Inet6Addr{IP: mask}
+}
+
+func cidrMask(ones, bits int) []byte {
+	m := make([]byte, bits/8)
+	for i := 0; i < ones; i++ {
+		m[i/8] |= 0x80 >> (i % 8)
+	}
+	return m
+}
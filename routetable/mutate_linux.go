@@ -0,0 +1,135 @@
+package routetable
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// DefaultRouteFlags is the flag set used by RouteAdd/RouteChange when the
+// caller passes 0, matching what `ip route add` sets for a gateway route.
+const DefaultRouteFlags = syscall.RTF_UP | syscall.RTF_GATEWAY | syscall.RTF_STATIC
+
+// RouteAdd installs a new route to dst via gw.
+func RouteAdd(dst netip.Prefix, gw netip.Addr, flags int) error {
+	return routeRequest(syscall.RTM_NEWROUTE, syscall.NLM_F_CREATE|syscall.NLM_F_EXCL, dst, gw, flags)
+}
+
+// RouteDelete removes the route to dst via gw.
+func RouteDelete(dst netip.Prefix, gw netip.Addr, flags int) error {
+	return routeRequest(syscall.RTM_DELROUTE, 0, dst, gw, flags)
+}
+
+// RouteChange updates the existing route to dst to go via gw.
+func RouteChange(dst netip.Prefix, gw netip.Addr, flags int) error {
+	return routeRequest(syscall.RTM_NEWROUTE, syscall.NLM_F_REPLACE, dst, gw, flags)
+}
+
+func routeRequest(msgType, extraFlags int, dst netip.Prefix, gw netip.Addr, flags int) error {
+	if flags == 0 {
+		flags = DefaultRouteFlags
+	}
+	rtType := syscall.RTN_UNICAST
+	if flags&RouteFlagBlackhole != 0 {
+		flags = flags &^ RouteFlagBlackhole
+		rtType = syscall.RTN_BLACKHOLE
+	}
+
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW, syscall.NETLINK_ROUTE)
+	if err != nil {
+		return fmt.Errorf("open netlink socket: %w", err)
+	}
+	defer syscall.Close(fd)
+
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return fmt.Errorf("bind netlink socket: %w", err)
+	}
+
+	family := syscall.AF_INET
+	if dst.Addr().Is6() {
+		family = syscall.AF_INET6
+	}
+
+	req := newNetlinkRtRequest(msgType, syscall.NLM_F_REQUEST|syscall.NLM_F_ACK|extraFlags, family, dst.Bits(), flags, rtType)
+	req = appendRouteAttr(req, syscall.RTA_DST, dst.Addr().AsSlice())
+	if gw.IsValid() {
+		req = appendRouteAttr(req, syscall.RTA_GATEWAY, gw.AsSlice())
+	}
+
+	if err := syscall.Sendto(fd, req, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return fmt.Errorf("send netlink request: %w", err)
+	}
+
+	return readNetlinkAck(fd)
+}
+
+// newNetlinkRtRequest builds an nlmsghdr + rtmsg header for a route
+// add/delete/change request.
+func newNetlinkRtRequest(msgType, nlFlags, family, dstLen, rtFlags, rtType int) []byte {
+	const hdrLen = syscall.NLMSG_HDRLEN
+	const rtMsgLen = syscall.SizeofRtMsg
+
+	buf := make([]byte, hdrLen+rtMsgLen)
+	hdr := (*syscall.NlMsghdr)(unsafe.Pointer(&buf[0]))
+	hdr.Len = uint32(len(buf))
+	hdr.Type = uint16(msgType)
+	hdr.Flags = uint16(nlFlags)
+	hdr.Seq = uint32(os.Getpid())
+	hdr.Pid = uint32(os.Getpid())
+
+	rtmsg := (*syscall.RtMsg)(unsafe.Pointer(&buf[hdrLen]))
+	rtmsg.Family = uint8(family)
+	rtmsg.Dst_len = uint8(dstLen)
+	rtmsg.Table = syscall.RT_TABLE_MAIN
+	rtmsg.Protocol = syscall.RTPROT_STATIC
+	rtmsg.Scope = syscall.RT_SCOPE_UNIVERSE
+	rtmsg.Type = uint8(rtType)
+	rtmsg.Flags = uint32(rtFlags)
+	return buf
+}
+
+// appendRouteAttr appends a netlink route attribute (rtattr + value,
+// padded to a 4-byte boundary) and fixes up the nlmsghdr length.
+func appendRouteAttr(req []byte, attrType int, value []byte) []byte {
+	attrLen := syscall.SizeofRtAttr + len(value)
+	padLen := (attrLen + 3) &^ 3
+
+	attr := make([]byte, padLen)
+	rtattr := (*syscall.RtAttr)(unsafe.Pointer(&attr[0]))
+	rtattr.Len = uint16(attrLen)
+	rtattr.Type = uint16(attrType)
+	copy(attr[syscall.SizeofRtAttr:], value)
+
+	req = append(req, attr...)
+	hdr := (*syscall.NlMsghdr)(unsafe.Pointer(&req[0]))
+	hdr.Len = uint32(len(req))
+	return req
+}
+
+// readNetlinkAck reads the NLMSG_ERROR reply the kernel sends for an
+// NLM_F_ACK request and returns its embedded errno, if any.
+func readNetlinkAck(fd int) error {
+	buf := make([]byte, os.Getpagesize())
+	n, err := syscall.Read(fd, buf)
+	if err != nil {
+		return fmt.Errorf("read netlink reply: %w", err)
+	}
+	msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+	if err != nil {
+		return fmt.Errorf("parse netlink reply: %w", err)
+	}
+	for _, m := range msgs {
+		if m.Header.Type != syscall.NLMSG_ERROR {
+			continue
+		}
+		errno := int32(binary.NativeEndian.Uint32(m.Data[:4]))
+		if errno != 0 {
+			return syscall.Errno(-errno)
+		}
+		return nil
+	}
+	return fmt.Errorf("no ack received for netlink request")
+}
@@ -0,0 +1,40 @@
+package routetable
+
+import (
+	"net/netip"
+	"testing"
+)
+
+// TestRouteAddDelete adds a blackhole route for a scratch, unused prefix
+// (TEST-NET-3, RFC 5737) and removes it again. It requires the
+// privileges needed to modify the kernel routing table, so it's skipped
+// when those aren't available.
+func TestRouteAddDelete(t *testing.T) {
+	dst := netip.MustParsePrefix("203.0.113.255/32")
+	gw := netip.MustParseAddr("127.0.0.1")
+	flags := DefaultRouteFlags | RouteFlagBlackhole
+
+	if err := RouteAdd(dst, gw, flags); err != nil {
+		t.Skipf("RouteAdd: %v (run as root/with CAP_NET_ADMIN to exercise this test)", err)
+	}
+	defer func() {
+		if err := RouteDelete(dst, gw, flags); err != nil {
+			t.Errorf("RouteDelete: %v", err)
+		}
+	}()
+
+	entries, err := Get(0)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	var found bool
+	for _, e := range entries {
+		if e.Dst == dst {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("added route to %v not found in routing table", dst)
+	}
+}
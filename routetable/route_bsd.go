@@ -0,0 +1,140 @@
+//go:build darwin || freebsd
+
+package routetable
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+
+	"golang.org/x/net/route"
+)
+
+// Get returns the current IPv4 and IPv6 kernel routing table. If max is
+// greater than zero, at most max entries are returned.
+func Get(max int) ([]RouteEntry, error) {
+	var entries []RouteEntry
+	for _, family := range []int{syscall.AF_INET, syscall.AF_INET6} {
+		pkt, err := route.FetchRIB(family, syscall.NET_RT_DUMP, 0)
+		if err != nil {
+			return nil, fmt.Errorf("fetch RIB: %w", err)
+		}
+		msgs, err := route.ParseRIB(route.RIBTypeRoute, pkt)
+		if err != nil {
+			return nil, fmt.Errorf("parse RIB: %w", err)
+		}
+
+		for _, msg := range msgs {
+			m, ok := msg.(*route.RouteMessage)
+			if !ok {
+				continue
+			}
+			e, ok, err := toRouteEntry(m)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			entries = append(entries, e)
+			if max > 0 && len(entries) >= max {
+				return entries, nil
+			}
+		}
+	}
+	return entries, nil
+}
+
+// toRouteEntry decodes a single RTM_GET-family route.RouteMessage into a
+// RouteEntry. The second return value is false for entries that
+// shouldSkip rejects, which callers should skip; a message without a
+// destination and gateway address is reported as an error.
+func toRouteEntry(msg *route.RouteMessage) (RouteEntry, bool, error) {
+	if shouldSkip(msg.Flags) {
+		return RouteEntry{}, false, nil
+	}
+
+	intf, err := net.InterfaceByIndex(msg.Index)
+	if err != nil {
+		return RouteEntry{}, false, fmt.Errorf("find interface %d: %w", msg.Index, err)
+	}
+
+	n := 0
+	for i, a := range msg.Addrs {
+		if a == nil {
+			n = i
+			break
+		}
+	}
+	if n < 2 {
+		return RouteEntry{}, false, fmt.Errorf("address should have at least 2 entries, but got %v", msg)
+	}
+
+	var (
+		addr netip.Addr
+		bits int
+	)
+	switch dst := msg.Addrs[0].(type) {
+	case *route.Inet4Addr:
+		addr, bits = netip.AddrFrom4(dst.IP), 32
+	case *route.Inet6Addr:
+		addr, bits = inet6Addr(dst), 128
+	default:
+		return RouteEntry{}, false, fmt.Errorf("destination address should be route.Inet4Addr or route.Inet6Addr, but got %v", msg.Addrs[0])
+	}
+
+	if n == 3 && msg.Addrs[2] != nil {
+		switch mask := msg.Addrs[2].(type) {
+		case *route.Inet4Addr:
+			bits, _ = net.IPv4Mask(mask.IP[0], mask.IP[1], mask.IP[2], mask.IP[3]).Size()
+		case *route.Inet6Addr:
+			bits, _ = net.IPMask(mask.IP[:]).Size()
+		default:
+			return RouteEntry{}, false, fmt.Errorf("3rd address should be a netmask, but got %v", msg.Addrs[2])
+		}
+	}
+
+	family := syscall.AF_INET
+	if addr.Is6() {
+		family = syscall.AF_INET6
+	}
+	e := RouteEntry{
+		Family:    family,
+		Dst:       netip.PrefixFrom(addr, bits),
+		Interface: intf.Name,
+		Flags:     flagNames(msg.Flags),
+		RawFlags:  msg.Flags,
+		Type:      msg.Type,
+	}
+
+	switch gw := msg.Addrs[1].(type) {
+	case *route.Inet4Addr:
+		e.Gateway = netip.AddrFrom4(gw.IP)
+	case *route.Inet6Addr:
+		e.Gateway = inet6Addr(gw)
+	case *route.LinkAddr:
+		if n == 3 {
+			e.GatewayInterface = fmt.Sprintf("link#%d", gw.Index)
+		} else {
+			e.GatewayLinkAddr = net.HardwareAddr(gw.Addr).String()
+		}
+	default:
+		return RouteEntry{}, false, fmt.Errorf("unknown gateway addrs %v", msg.Addrs[1])
+	}
+
+	return e, true, nil
+}
+
+// inet6Addr converts a route.Inet6Addr to a netip.Addr, preserving the
+// zone (scope) when the kernel reports a non-zero ZoneID, e.g. for
+// link-local destinations reached via RTF_IFSCOPE.
+func inet6Addr(a *route.Inet6Addr) netip.Addr {
+	addr := netip.AddrFrom16(a.IP)
+	if a.ZoneID != 0 {
+		if zone, err := net.InterfaceByIndex(a.ZoneID); err == nil {
+			return addr.WithZone(zone.Name)
+		}
+	}
+	return addr
+}
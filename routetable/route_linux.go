@@ -0,0 +1,154 @@
+package routetable
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+	"unsafe"
+)
+
+// Get returns the current IPv4 and IPv6 main routing table, read via netlink. If
+// max is greater than zero, at most max entries are returned.
+func Get(max int) ([]RouteEntry, error) {
+	data, err := syscall.NetlinkRIB(syscall.RTM_GETROUTE, syscall.AF_UNSPEC)
+	if err != nil {
+		return nil, fmt.Errorf("netlink RIB: %w", err)
+	}
+	msgs, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse netlink message: %w", err)
+	}
+
+	var entries []RouteEntry
+	for _, msg := range msgs {
+		if msg.Header.Type != syscall.RTM_NEWROUTE {
+			continue
+		}
+		e, ok, err := toRouteEntry(msg)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		entries = append(entries, e)
+		if max > 0 && len(entries) >= max {
+			break
+		}
+	}
+	return entries, nil
+}
+
+// toRouteEntry decodes a single RTM_NEWROUTE netlink message into a
+// RouteEntry. The second return value is false for messages outside the
+// main routing table, which Get/Monitor should skip. LookupRoute decodes
+// through rtMsgFromNetlink/decodeRouteEntry directly instead, since a
+// kernel route-get reply for a local address is correctly reported in
+// RT_TABLE_LOCAL rather than RT_TABLE_MAIN.
+func toRouteEntry(msg syscall.NetlinkMessage) (RouteEntry, bool, error) {
+	rtmsg, err := rtMsgFromNetlink(msg)
+	if err != nil {
+		return RouteEntry{}, false, err
+	}
+	if rtmsg.Table != syscall.RT_TABLE_MAIN {
+		return RouteEntry{}, false, nil
+	}
+	e, err := decodeRouteEntry(msg, rtmsg)
+	if err != nil {
+		return RouteEntry{}, false, err
+	}
+	return e, true, nil
+}
+
+// rtMsgFromNetlink extracts the rtmsg header embedded at the start of a
+// route netlink message's payload.
+func rtMsgFromNetlink(msg syscall.NetlinkMessage) (*syscall.RtMsg, error) {
+	if len(msg.Data) < syscall.SizeofRtMsg {
+		return nil, fmt.Errorf("route message too short: %d bytes", len(msg.Data))
+	}
+	return (*syscall.RtMsg)(unsafe.Pointer(&msg.Data[0])), nil
+}
+
+// decodeRouteEntry decodes a route netlink message into a RouteEntry,
+// given its already-extracted rtmsg header. It applies no table
+// filtering, unlike toRouteEntry.
+func decodeRouteEntry(msg syscall.NetlinkMessage, rtmsg *syscall.RtMsg) (RouteEntry, error) {
+	attrs, err := syscall.ParseNetlinkRouteAttr(&msg)
+	if err != nil {
+		return RouteEntry{}, fmt.Errorf("parse route attrs: %w", err)
+	}
+
+	e := RouteEntry{
+		Family:   int(rtmsg.Family),
+		Flags:    flagNames(int(rtmsg.Flags)),
+		RawFlags: int(rtmsg.Flags),
+		Type:     int(msg.Header.Type),
+	}
+
+	var dst netip.Addr
+	haveDst := false
+	var oif int
+	for _, a := range attrs {
+		switch a.Attr.Type {
+		case syscall.RTA_DST:
+			dst, err = addrFromBytes(int(rtmsg.Family), a.Value)
+			if err != nil {
+				return RouteEntry{}, err
+			}
+			haveDst = true
+		case syscall.RTA_GATEWAY:
+			e.Gateway, err = addrFromBytes(int(rtmsg.Family), a.Value)
+			if err != nil {
+				return RouteEntry{}, err
+			}
+		case syscall.RTA_OIF:
+			oif = int(binary.NativeEndian.Uint32(a.Value))
+		}
+	}
+
+	if haveDst {
+		e.Dst = netip.PrefixFrom(dst, int(rtmsg.Dst_len))
+	} else {
+		zero, err := addrFromBytes(int(rtmsg.Family), make([]byte, addrLen(int(rtmsg.Family))))
+		if err != nil {
+			return RouteEntry{}, err
+		}
+		e.Dst = netip.PrefixFrom(zero, int(rtmsg.Dst_len))
+	}
+
+	if oif != 0 {
+		intf, err := net.InterfaceByIndex(oif)
+		if err != nil {
+			return RouteEntry{}, fmt.Errorf("find interface %d: %w", oif, err)
+		}
+		e.Interface = intf.Name
+	}
+
+	return e, nil
+}
+
+func addrLen(family int) int {
+	if family == syscall.AF_INET6 {
+		return 16
+	}
+	return 4
+}
+
+func addrFromBytes(family int, b []byte) (netip.Addr, error) {
+	switch family {
+	case syscall.AF_INET:
+		if len(b) != 4 {
+			return netip.Addr{}, fmt.Errorf("want 4 bytes for AF_INET address, got %d", len(b))
+		}
+		return netip.AddrFrom4([4]byte(b)), nil
+	case syscall.AF_INET6:
+		if len(b) != 16 {
+			return netip.Addr{}, fmt.Errorf("want 16 bytes for AF_INET6 address, got %d", len(b))
+		}
+		return netip.AddrFrom16([16]byte(b)), nil
+	default:
+		return netip.Addr{}, fmt.Errorf("unsupported address family %d", family)
+	}
+}
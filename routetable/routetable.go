@@ -0,0 +1,62 @@
+// Package routetable provides a cross-platform view of the kernel routing
+// table. Darwin and FreeBSD are read via the PF_ROUTE/sysctl interface
+// exposed by golang.org/x/net/route, while Linux is read via netlink
+// (RTM_GETROUTE). All backends normalize their results into RouteEntry so
+// callers don't need to know which OS they're running on.
+package routetable
+
+import "net/netip"
+
+// RouteEntry is a single kernel routing table entry, normalized across
+// platforms.
+type RouteEntry struct {
+	// Family is the address family of Dst, e.g. syscall.AF_INET or
+	// syscall.AF_INET6.
+	Family int
+
+	// Dst is the destination network. The default route has a prefix
+	// length of 0 (0.0.0.0/0 or ::/0).
+	Dst netip.Prefix
+
+	// Gateway is the next-hop address, if the route has one.
+	Gateway netip.Addr
+
+	// GatewayInterface is the name of the interface a gateway resolves
+	// through, when the gateway itself is link-layer (e.g. a directly
+	// attached route with no IP next hop).
+	GatewayInterface string
+
+	// GatewayLinkAddr is the link-layer (MAC) address of the gateway,
+	// when known.
+	GatewayLinkAddr string
+
+	// Interface is the name of the outgoing interface for this route.
+	Interface string
+
+	// Src is a source-address hint for this route. It's only populated
+	// by LookupRoute.
+	Src netip.Addr
+
+	// MTU is the path MTU for this route, where the kernel reports one.
+	// It's only populated by LookupRoute.
+	MTU int
+
+	// Flags is the human-readable decoding of RawFlags, e.g.
+	// []string{"RTF_UP", "RTF_GATEWAY"}.
+	Flags []string
+
+	// RawFlags is the undecoded, platform-specific route flags bitmask.
+	RawFlags int
+
+	// Type is the platform-specific route message type the entry was
+	// decoded from, e.g. RTM_GET on darwin/freebsd or RTM_NEWROUTE on
+	// linux.
+	Type int
+}
+
+// RouteFlagBlackhole marks a route as a blackhole (silently discard
+// matching traffic) when passed to RouteAdd/RouteChange. Darwin/freebsd
+// and Linux express this differently at the kernel level (an RTF_*
+// flag vs. an RTN_BLACKHOLE route type), so RouteAdd/RouteChange
+// translate this bit to whatever the platform needs.
+const RouteFlagBlackhole = 1 << 30